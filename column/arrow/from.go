@@ -0,0 +1,58 @@
+package arrow
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/vahid-sohrabloo/chconn/v3/column"
+)
+
+// DateTimeFromArrow appends every value of arr (a *array.Timestamp) to c,
+// preserving nulls. It does not reset c first, so it can be used to append
+// several Arrow chunks into a single insert column.
+func DateTimeFromArrow(c *column.DateTime, arr arrow.Array) error {
+	ts, ok := arr.(*array.Timestamp)
+	if !ok {
+		return fmt.Errorf("chconn/arrow: DateTimeFromArrow: expected *array.Timestamp, got %T", arr)
+	}
+	for i := 0; i < ts.Len(); i++ {
+		if ts.IsNull(i) {
+			c.AppendP(nil)
+			continue
+		}
+		t := ts.Value(i).ToTime(arrow.Second)
+		c.AppendP(&t)
+	}
+	return nil
+}
+
+// Uint64FromArrow appends every value of arr (a *array.Uint64) to c,
+// preserving nulls.
+func Uint64FromArrow(c *column.Uint64, arr arrow.Array) error {
+	u, ok := arr.(*array.Uint64)
+	if !ok {
+		return fmt.Errorf("chconn/arrow: Uint64FromArrow: expected *array.Uint64, got %T", arr)
+	}
+	for i := 0; i < u.Len(); i++ {
+		if u.IsNull(i) {
+			c.AppendP(nil)
+			continue
+		}
+		v := u.Value(i)
+		c.AppendP(&v)
+	}
+	return nil
+}
+
+// Int16FromArrow appends every value of arr (a *array.Int16) to c.
+func Int16FromArrow(c *column.Int16, arr arrow.Array) error {
+	i16, ok := arr.(*array.Int16)
+	if !ok {
+		return fmt.Errorf("chconn/arrow: Int16FromArrow: expected *array.Int16, got %T", arr)
+	}
+	for i := 0; i < i16.Len(); i++ {
+		c.Append(i16.Value(i))
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package arrow
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+)
+
+// BlockColumn pairs a chconn column with the name it should carry in the
+// resulting arrow.Record and the conversion that turns it into an
+// arrow.Array (DateTimeToArrow, Uint64ToArrow, Int16ToArrow,
+// ArrayNullableToArrow, ...).
+type BlockColumn struct {
+	Name    string
+	ToArrow func(pool memory.Allocator) arrow.Array
+}
+
+// Block is the minimal view of a SelectStmt result that ToArrowRecord needs:
+// its columns, in the same order they were selected.
+type Block struct {
+	Columns []BlockColumn
+}
+
+// ToArrowRecord converts every column of b and stitches them into a single
+// arrow.Record matching schema, so a SelectStmt result can be piped into
+// arrow-backed engines with zero user-side conversion loops: callers build
+// the Block's column list once per query shape, not a conversion loop per
+// call.
+//
+// schema must declare its fields in the same order as b.Columns. It is an
+// error for schema's field count not to match len(b.Columns), for a field
+// name not to match its column's Name, or for converted columns to
+// disagree on their row count.
+func (b *Block) ToArrowRecord(pool memory.Allocator, schema *arrow.Schema) (arrow.Record, error) {
+	if len(b.Columns) != len(schema.Fields()) {
+		return nil, fmt.Errorf("chconn/arrow: ToArrowRecord: schema has %d fields but got %d columns",
+			len(schema.Fields()), len(b.Columns))
+	}
+
+	arrays := make([]arrow.Array, len(b.Columns))
+	numRows := -1
+	for i, c := range b.Columns {
+		if schema.Field(i).Name != c.Name {
+			return nil, fmt.Errorf("chconn/arrow: ToArrowRecord: schema field %d is %q, got column %q",
+				i, schema.Field(i).Name, c.Name)
+		}
+		arrays[i] = c.ToArrow(pool)
+		switch {
+		case numRows == -1:
+			numRows = arrays[i].Len()
+		case arrays[i].Len() != numRows:
+			return nil, fmt.Errorf("chconn/arrow: ToArrowRecord: column %q has %d rows, but column %q has %d",
+				c.Name, arrays[i].Len(), b.Columns[0].Name, numRows)
+		}
+	}
+
+	return array.NewRecord(schema, arrays, int64(numRows)), nil
+}
@@ -0,0 +1,293 @@
+package column
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// CraftColumnKind tells a CraftColumn how to expand its staged bytes back
+// into ClickHouse's native wire layout at WriteTo time.
+type CraftColumnKind byte
+
+const (
+	// CraftUint8/16/32/64 are fixed-width unsigned integers, staged via
+	// AppendUint and group-varint packed: four values become one length
+	// byte followed by their four varints.
+	CraftUint8 CraftColumnKind = iota + 1
+	CraftUint16
+	CraftUint32
+	CraftUint64
+	// CraftTimestamp32/64 are DateTime/DateTime64 values, staged via
+	// AppendTimestamp as a delta from the column's first tick, varint
+	// encoded, and expanded back to 4 or 8 little-endian bytes per row.
+	CraftTimestamp32
+	CraftTimestamp64
+	// CraftDictKey is a LowCardinality index, staged via AppendDictKey as a
+	// varint and expanded back to a 4-byte little-endian index per row.
+	CraftDictKey
+)
+
+// width returns the native wire width, in bytes, this kind expands to.
+func (k CraftColumnKind) width() int {
+	switch k {
+	case CraftUint8:
+		return 1
+	case CraftUint16:
+		return 2
+	case CraftUint32, CraftTimestamp32, CraftDictKey:
+		return 4
+	case CraftUint64, CraftTimestamp64:
+		return 8
+	default:
+		return 0
+	}
+}
+
+// CraftBuffer is an opt-in staging area for batch inserts, modeled on
+// TiCDC's craft binary format. Without it, every column's Append grows its
+// own writerData slice at full wire width. CraftBuffer instead hands each
+// column its own region (via Column), a separate buffer owned by its
+// CraftColumn (the regions are not carved out of one shared allocation),
+// compactly encoded:
+//
+//   - fixed-width integers are group-varint packed: four values become one
+//     length byte followed by four varints (see CraftColumn.AppendUint)
+//   - DateTime/DateTime64 values are stored as a delta from the column's
+//     first timestamp, varint encoded (see CraftColumn.AppendTimestamp)
+//   - nullability lives in a packed bitmap per column, not a colNullable.b
+//     allocation per column
+//   - LowCardinality keys are stored as varints (see CraftColumn.AppendDictKey)
+//
+// WriteTo expands every column's region back into ClickHouse's native wire
+// layout in column order, so the full-width per-column writerData slabs are
+// never built for large batch inserts.
+type CraftBuffer struct {
+	columns []*CraftColumn
+}
+
+// NewCraftBuffer returns an empty CraftBuffer ready to stage rows for one insert batch.
+func NewCraftBuffer() *CraftBuffer {
+	return &CraftBuffer{}
+}
+
+// Column returns the staging region for the i-th column of the batch,
+// creating it (with the given kind) on first use. Every column in the
+// insert gets its own region: staged bytes from one column are never
+// interleaved with another's.
+func (b *CraftBuffer) Column(i int, kind CraftColumnKind) *CraftColumn {
+	for len(b.columns) <= i {
+		b.columns = append(b.columns, nil)
+	}
+	if b.columns[i] == nil {
+		b.columns[i] = &CraftColumn{kind: kind}
+	}
+	return b.columns[i]
+}
+
+// WriteTo expands every column's region, in column order, back into
+// ClickHouse's native wire layout and writes it to w.
+func (b *CraftBuffer) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	for i, col := range b.columns {
+		if col == nil {
+			continue
+		}
+		n, err := col.writeTo(w)
+		total += n
+		if err != nil {
+			return total, fmt.Errorf("chconn: expanding craft column %d: %w", i, err)
+		}
+	}
+	return total, nil
+}
+
+// Reset clears every column's region so the buffer can stage the next
+// insert batch.
+func (b *CraftBuffer) Reset() {
+	for _, col := range b.columns {
+		if col != nil {
+			col.reset()
+		}
+	}
+}
+
+// CraftColumn is one column's staging region inside a shared CraftBuffer.
+type CraftColumn struct {
+	kind       CraftColumnKind
+	numRow     int
+	nullBits   []byte
+	nullBitPos int
+	buf        []byte
+	group      [4]uint64
+	groupLen   int
+	tsBase     int64
+	tsBaseSet  bool
+}
+
+// AppendNullBit packs one row's nullability into this column's own bitmap.
+func (c *CraftColumn) AppendNullBit(isNil bool) {
+	byteIndex := c.nullBitPos / 8
+	for len(c.nullBits) <= byteIndex {
+		c.nullBits = append(c.nullBits, 0)
+	}
+	if isNil {
+		c.nullBits[byteIndex] |= 1 << uint(c.nullBitPos%8)
+	}
+	c.nullBitPos++
+}
+
+// AppendUint stages a fixed-width unsigned integer for a
+// CraftUint8/16/32/64 column, group-varint packing every four values into
+// one length byte followed by their four varints.
+func (c *CraftColumn) AppendUint(v uint64) {
+	c.numRow++
+	c.group[c.groupLen] = v
+	c.groupLen++
+	if c.groupLen == len(c.group) {
+		c.flushGroup()
+	}
+}
+
+func (c *CraftColumn) flushGroup() {
+	if c.groupLen == 0 {
+		return
+	}
+	// The prefix byte is the number of varints in the group (<=4), not
+	// their encoded byte length: expandGroupVarint bounds its decode loop
+	// on this count, and a multi-byte varint (any value >= 128) would
+	// desync that bound from an encoded-length prefix.
+	c.buf = append(c.buf, byte(c.groupLen))
+	for i := 0; i < c.groupLen; i++ {
+		c.buf = binary.AppendUvarint(c.buf, c.group[i])
+	}
+	c.groupLen = 0
+}
+
+// AppendTimestamp stages a DateTime/DateTime64 tick value, for a
+// CraftTimestamp32/64 column, as a delta from the column's first tick,
+// varint encoded.
+func (c *CraftColumn) AppendTimestamp(ticks int64) {
+	c.numRow++
+	if !c.tsBaseSet {
+		c.tsBase = ticks
+		c.tsBaseSet = true
+	}
+	c.buf = binary.AppendVarint(c.buf, ticks-c.tsBase)
+}
+
+// AppendDictKey stages a LowCardinality key, for a CraftDictKey column, as a varint.
+func (c *CraftColumn) AppendDictKey(key int) {
+	c.numRow++
+	c.buf = binary.AppendUvarint(c.buf, uint64(key))
+}
+
+// writeTo expands this column's staged values back into ClickHouse's native
+// fixed-width wire layout and writes its null map, if any, expanded from
+// nullBits to one byte per row, followed by the expanded values, to w.
+func (c *CraftColumn) writeTo(w io.Writer) (int64, error) {
+	c.flushGroup()
+
+	var total int64
+	if c.nullBitPos > 0 {
+		nullMap := make([]byte, c.nullBitPos)
+		for i := range nullMap {
+			nullMap[i] = (c.nullBits[i/8] >> uint(i%8)) & 1
+		}
+		n, err := w.Write(nullMap)
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+
+	width := c.kind.width()
+	if width == 0 {
+		return total, fmt.Errorf("unknown craft column kind %d", c.kind)
+	}
+
+	var out []byte
+	var err error
+	switch c.kind {
+	case CraftUint8, CraftUint16, CraftUint32, CraftUint64:
+		out, err = c.expandGroupVarint(width)
+	case CraftTimestamp32, CraftTimestamp64:
+		out, err = c.expandTimestamps(width)
+	case CraftDictKey:
+		out, err = c.expandDictKeys()
+	}
+	if err != nil {
+		return total, err
+	}
+
+	n, err := w.Write(out)
+	total += int64(n)
+	return total, err
+}
+
+func (c *CraftColumn) expandGroupVarint(width int) ([]byte, error) {
+	out := make([]byte, 0, c.numRow*width)
+	buf := c.buf
+	row := 0
+	for row < c.numRow {
+		if len(buf) == 0 {
+			return nil, fmt.Errorf("truncated group-varint column at row %d", row)
+		}
+		groupCount := int(buf[0])
+		buf = buf[1:]
+		for j := 0; j < groupCount && row < c.numRow; j++ {
+			v, n := binary.Uvarint(buf)
+			if n <= 0 {
+				return nil, fmt.Errorf("invalid varint in group at row %d", row)
+			}
+			buf = buf[n:]
+			out = appendUintWidth(out, v, width)
+			row++
+		}
+	}
+	return out, nil
+}
+
+func (c *CraftColumn) expandTimestamps(width int) ([]byte, error) {
+	out := make([]byte, 0, c.numRow*width)
+	buf := c.buf
+	for row := 0; row < c.numRow; row++ {
+		delta, n := binary.Varint(buf)
+		if n <= 0 {
+			return nil, fmt.Errorf("invalid timestamp delta at row %d", row)
+		}
+		buf = buf[n:]
+		out = appendUintWidth(out, uint64(c.tsBase+delta), width)
+	}
+	return out, nil
+}
+
+func (c *CraftColumn) expandDictKeys() ([]byte, error) {
+	out := make([]byte, 0, c.numRow*4)
+	buf := c.buf
+	for row := 0; row < c.numRow; row++ {
+		key, n := binary.Uvarint(buf)
+		if n <= 0 {
+			return nil, fmt.Errorf("invalid dict key varint at row %d", row)
+		}
+		buf = buf[n:]
+		out = appendUintWidth(out, key, 4)
+	}
+	return out, nil
+}
+
+func appendUintWidth(out []byte, v uint64, width int) []byte {
+	var tmp [8]byte
+	binary.LittleEndian.PutUint64(tmp[:], v)
+	return append(out, tmp[:width]...)
+}
+
+// reset clears this column's region so it can stage the next insert batch.
+func (c *CraftColumn) reset() {
+	c.numRow = 0
+	c.buf = c.buf[:0]
+	c.nullBits = c.nullBits[:0]
+	c.nullBitPos = 0
+	c.groupLen = 0
+	c.tsBaseSet = false
+}
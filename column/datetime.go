@@ -2,15 +2,20 @@ package column
 
 import (
 	"encoding/binary"
+	"fmt"
+	"strings"
 	"time"
 )
 
 // DateTime use for DateTime ClickHouse DataType
 type DateTime struct {
 	column
-	val  time.Time
-	dict map[time.Time]int
-	keys []int
+	val   time.Time
+	dict  map[time.Time]int
+	keys  []int
+	loc   *time.Location
+	craft *CraftColumn
+	lazy  *LazyReader
 }
 
 // NewDateTime return new DateTime for DateTime ClickHouse DataType
@@ -25,15 +30,117 @@ func NewDateTime(nullable bool) *DateTime {
 	}
 }
 
+// NewDateTimeTZ return new DateTime for DateTime('TZ') ClickHouse DataType.
+//
+// Every value read from or written to the column is interpreted in loc
+// instead of the process's local zone.
+func NewDateTimeTZ(nullable bool, loc *time.Location) *DateTime {
+	c := NewDateTime(nullable)
+	c.loc = loc
+	return c
+}
+
+// NewDateTimeFromType constructs a DateTime column for chType, a ClickHouse
+// type string such as "DateTime" or "DateTime('Europe/Amsterdam')": it
+// parses chType's timezone, if any, and stashes it on the column so every
+// decoded value comes back through .In(loc). Nothing in this package calls
+// it; a column made with NewDateTime has no timezone and decodes in the
+// process's local zone.
+func NewDateTimeFromType(nullable bool, chType string) (*DateTime, error) {
+	loc, err := parseDateTimeTimeZone(chType)
+	if err != nil {
+		return nil, err
+	}
+	return NewDateTimeTZ(nullable, loc), nil
+}
+
+// parseDateTimeTimeZone extracts the timezone name from a column type string
+// of the form DateTime('TZ') or DateTime (no timezone). It returns nil, nil
+// when the type has no explicit timezone.
+func parseDateTimeTimeZone(chType string) (*time.Location, error) {
+	start := strings.IndexByte(chType, '\'')
+	if start < 0 {
+		return nil, nil
+	}
+	end := strings.IndexByte(chType[start+1:], '\'')
+	if end < 0 {
+		return nil, fmt.Errorf("chconn: invalid DateTime type string %q", chType)
+	}
+	name := chType[start+1 : start+1+end]
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("chconn: invalid DateTime timezone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// UseCraftBuffer switches Append/AppendEmpty/AppendP into staging through
+// craft instead of growing the column's own writerData slab, so the column
+// stages delta-varint encoded values in its CraftBuffer region instead of
+// 4 bytes per row. Call it once per insert,
+// before any Append, with a CraftColumn obtained from
+// CraftBuffer.Column(i, CraftTimestamp32).
+func (c *DateTime) UseCraftBuffer(craft *CraftColumn) {
+	c.craft = craft
+}
+
+// toLoc applies the column's timezone, if any, without mutating the
+// original UTC-seconds decoding.
+func (c *DateTime) toLoc(t time.Time) time.Time {
+	if c.loc == nil {
+		return t
+	}
+	return t.In(c.loc)
+}
+
+// UseLazyReader switches the column into lazy mode: instead of indexing
+// into a fully buffered c.b, Next/ReadAll/Fill read c.size bytes per row
+// from lazy on demand. Call it once per block, before Next/ReadAll/Fill, as
+// described on NewLazyReaderIfOversized.
+//
+// Lazy mode only covers the plain (non-nullable) value stream, so
+// UseLazyReader panics if called on a nullable column.
+func (c *DateTime) UseLazyReader(lazy *LazyReader) {
+	if c.nullable {
+		panic("chconn: UseLazyReader is not supported on a nullable column")
+	}
+	c.lazy = lazy
+}
+
+// readRow reads row i's seconds-since-epoch, from lazy when the column is
+// in lazy mode or from the buffered c.b otherwise.
+//
+// A read error from the lazy source (e.g. a truncated spill file) is fatal
+// to the block: the caller has no way to recover a partially decoded
+// result, so we panic rather than silently return a zero value.
+func (c *DateTime) readRow(row int) time.Time {
+	if c.lazy != nil {
+		var buf [DatetimeSize]byte
+		if err := c.lazy.ReadRow(buf[:], row); err != nil {
+			panic(fmt.Errorf("chconn: reading lazy row %d: %w", row, err))
+		}
+		return c.toLoc(time.Unix(int64(binary.LittleEndian.Uint32(buf[:])), 0))
+	}
+	return c.toLoc(time.Unix(int64(binary.LittleEndian.Uint32(c.b[row*c.size:row*c.size+c.size])), 0))
+}
+
 // Next forward pointer to the next value. Returns false if there are no more values.
 //
 // Use with Value() or ValueP()
 func (c *DateTime) Next() bool {
+	if c.lazy != nil {
+		if c.i >= c.numRow {
+			return false
+		}
+		c.val = c.readRow(c.i)
+		c.i++
+		return true
+	}
 	if c.i >= c.totalByte {
 		return false
 	}
 	c.i += c.size
-	c.val = time.Unix(int64(binary.LittleEndian.Uint32(c.b[c.i-c.size:c.i])), 0)
+	c.val = c.toLoc(time.Unix(int64(binary.LittleEndian.Uint32(c.b[c.i-c.size:c.i])), 0))
 	return true
 }
 
@@ -46,9 +153,15 @@ func (c *DateTime) Value() time.Time {
 
 // ReadAll read all value in this block and append to the input slice
 func (c *DateTime) ReadAll(value *[]time.Time) {
+	if c.lazy != nil {
+		for i := 0; i < c.numRow; i++ {
+			*value = append(*value, c.readRow(i))
+		}
+		return
+	}
 	for i := 0; i < c.totalByte; i += c.size {
 		*value = append(*value,
-			time.Unix(int64(binary.LittleEndian.Uint32(c.b[i:i+c.size])), 0))
+			c.toLoc(time.Unix(int64(binary.LittleEndian.Uint32(c.b[i:i+c.size])), 0)))
 	}
 }
 
@@ -56,8 +169,15 @@ func (c *DateTime) ReadAll(value *[]time.Time) {
 //
 // NOTE: A slice that is longer than the remaining data is not safe to pass.
 func (c *DateTime) Fill(value []time.Time) {
+	if c.lazy != nil {
+		for i := range value {
+			value[i] = c.readRow(c.i)
+			c.i++
+		}
+		return
+	}
 	for i := range value {
-		value[i] = time.Unix(int64(binary.LittleEndian.Uint32(c.b[c.i:c.i+c.size])), 0)
+		value[i] = c.toLoc(time.Unix(int64(binary.LittleEndian.Uint32(c.b[c.i:c.i+c.size])), 0))
 		c.i += c.size
 	}
 }
@@ -84,7 +204,7 @@ func (c *DateTime) ReadAllP(value *[]*time.Time) {
 			*value = append(*value, nil)
 			continue
 		}
-		val := time.Unix(int64(binary.LittleEndian.Uint32(c.b[i:i+c.size])), 0)
+		val := c.toLoc(time.Unix(int64(binary.LittleEndian.Uint32(c.b[i:i+c.size])), 0))
 		*value = append(*value, &val)
 	}
 }
@@ -101,7 +221,7 @@ func (c *DateTime) FillP(value []*time.Time) {
 			c.i += c.size
 			continue
 		}
-		val := time.Unix(int64(binary.LittleEndian.Uint32(c.b[c.i:c.i+c.size])), 0)
+		val := c.toLoc(time.Unix(int64(binary.LittleEndian.Uint32(c.b[c.i:c.i+c.size])), 0))
 		value[i] = &val
 		c.i += c.size
 	}
@@ -110,11 +230,18 @@ func (c *DateTime) FillP(value []*time.Time) {
 // Append value for insert
 func (c *DateTime) Append(v time.Time) {
 	c.numRow++
+	timestamp := v.Unix()
 	if v.Unix() <= 0 {
+		timestamp = 0
+	}
+	if c.craft != nil {
+		c.craft.AppendTimestamp(timestamp)
+		return
+	}
+	if timestamp == 0 {
 		c.writerData = append(c.writerData, emptyByte[:c.size]...)
 		return
 	}
-	timestamp := v.Unix()
 	c.writerData = append(c.writerData,
 		byte(timestamp),
 		byte(timestamp>>8),
@@ -126,6 +253,10 @@ func (c *DateTime) Append(v time.Time) {
 // AppendEmpty append empty value for insert
 func (c *DateTime) AppendEmpty() {
 	c.numRow++
+	if c.craft != nil {
+		c.craft.AppendTimestamp(0)
+		return
+	}
 	c.writerData = append(c.writerData, emptyByte[:c.size]...)
 }
 
@@ -137,10 +268,18 @@ func (c *DateTime) AppendEmpty() {
 func (c *DateTime) AppendP(v *time.Time) {
 	if v == nil {
 		c.AppendEmpty()
-		c.colNullable.Append(1)
+		if c.craft != nil {
+			c.craft.AppendNullBit(true)
+		} else {
+			c.colNullable.Append(1)
+		}
 		return
 	}
-	c.colNullable.Append(0)
+	if c.craft != nil {
+		c.craft.AppendNullBit(false)
+	} else {
+		c.colNullable.Append(0)
+	}
 	c.Append(*v)
 }
 
@@ -191,6 +330,38 @@ func (c *DateTime) Keys() []int {
 	return c.keys
 }
 
+// DictValues returns the dictionary values for LowCardinality data type, ordered by their key.
+//
+// Only use for LowCardinality data type
+func (c *DateTime) DictValues() []time.Time {
+	values := make([]time.Time, len(c.dict))
+	for v, k := range c.dict {
+		values[k] = v
+	}
+	return values
+}
+
+// DictValuesP returns the dictionary values for LowCardinality data type, indexed
+// the same way as Keys(): if the column is nullable, index 0 is the null
+// sentinel (represented as a nil entry) and dictionary values start at index 1.
+//
+// Only use for LowCardinality data type
+func (c *DateTime) DictValuesP() []*time.Time {
+	values := c.DictValues()
+	if !c.nullable {
+		out := make([]*time.Time, len(values))
+		for i := range values {
+			out[i] = &values[i]
+		}
+		return out
+	}
+	out := make([]*time.Time, len(values)+1)
+	for i := range values {
+		out[i+1] = &values[i]
+	}
+	return out
+}
+
 // Reset all status and buffer data
 //
 // Reading data does not require a reset after each read. The reset will be triggered automatically.
@@ -200,4 +371,8 @@ func (c *DateTime) Reset() {
 	c.column.Reset()
 	c.keys = c.keys[:0]
 	c.dict = make(map[time.Time]int)
+	if c.lazy != nil {
+		c.lazy.Close()
+		c.lazy = nil
+	}
 }
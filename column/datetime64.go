@@ -0,0 +1,365 @@
+package column
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// DateTime64 use for DateTime64(precision[, 'TZ']) ClickHouse DataType
+//
+// Values are stored as the number of 10^precision ticks since the Unix
+// epoch, exactly like ClickHouse's own wire representation, so Precision()
+// rows keep their sub-second part through read, write and LowCardinality.
+type DateTime64 struct {
+	column
+	val       time.Time
+	precision uint8
+	scale     int64
+	loc       *time.Location
+	dict      map[int64]int
+	keys      []int
+	lazy      *LazyReader
+}
+
+// NewDateTime64 return new DateTime64 for DateTime64(precision) ClickHouse
+// DataType. It panics if precision is outside the valid 0-9 range; use
+// NewDateTime64Checked if precision comes from untrusted input.
+func NewDateTime64(nullable bool, precision uint8) *DateTime64 {
+	c, err := NewDateTime64Checked(nullable, precision)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// NewDateTime64Checked is NewDateTime64TZ with nil loc, returning an error
+// instead of panicking or silently corrupting on an out-of-range precision.
+func NewDateTime64Checked(nullable bool, precision uint8) (*DateTime64, error) {
+	return NewDateTime64TZChecked(nullable, precision, nil)
+}
+
+// NewDateTime64TZ return new DateTime64 for DateTime64(precision, 'TZ')
+// ClickHouse DataType.
+//
+// Every value read from or written to the column is interpreted in loc
+// instead of the process's local zone.
+//
+// It panics if precision is outside the valid 0-9 range; use
+// NewDateTime64TZChecked if precision comes from untrusted input.
+func NewDateTime64TZ(nullable bool, precision uint8, loc *time.Location) *DateTime64 {
+	c, err := NewDateTime64TZChecked(nullable, precision, loc)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// NewDateTime64TZChecked validates precision before constructing the
+// column: precision > 9 overflows precisionScale past 1e9, which makes
+// toTicks/Append divide by zero instead of returning a clean error.
+func NewDateTime64TZChecked(nullable bool, precision uint8, loc *time.Location) (*DateTime64, error) {
+	if precision > 9 {
+		return nil, fmt.Errorf("chconn: DateTime64 precision must be 0-9, got %d", precision)
+	}
+	return &DateTime64{
+		dict:      make(map[int64]int),
+		precision: precision,
+		scale:     precisionScale(precision),
+		loc:       loc,
+		column: column{
+			nullable:    nullable,
+			colNullable: newNullable(),
+			size:        Uint64Size,
+		},
+	}, nil
+}
+
+// precisionScale returns 10^precision, the number of ticks per second for
+// the given DateTime64 precision. It does not clamp precision;
+// NewDateTime64TZChecked rejects precision > 9 before calling it.
+func precisionScale(precision uint8) int64 {
+	scale := int64(1)
+	for i := uint8(0); i < precision; i++ {
+		scale *= 10
+	}
+	return scale
+}
+
+// Precision returns the number of decimal digits of sub-second precision
+// this column was created with (0-9).
+func (c *DateTime64) Precision() uint8 {
+	return c.precision
+}
+
+func (c *DateTime64) fromTicks(ticks int64) time.Time {
+	sec := ticks / c.scale
+	nanos := (ticks % c.scale) * (1e9 / c.scale)
+	t := time.Unix(sec, nanos)
+	if c.loc != nil {
+		return t.In(c.loc)
+	}
+	return t
+}
+
+func (c *DateTime64) toTicks(v time.Time) (int64, error) {
+	nanos := int64(v.Nanosecond())
+	if nanos%(1e9/c.scale) != 0 {
+		return 0, fmt.Errorf("chconn: %s is not representable at DateTime64(%d) precision",
+			v.Format(time.RFC3339Nano), c.precision)
+	}
+	return v.Unix()*c.scale + nanos/(1e9/c.scale), nil
+}
+
+// UseLazyReader switches the column into lazy mode: instead of indexing
+// into a fully buffered c.b, Next/ReadAll/Fill read c.size bytes per row
+// from lazy on demand. Call it once per block, before Next/ReadAll/Fill, as
+// described on NewLazyReaderIfOversized.
+//
+// Lazy mode only covers the plain (non-nullable) value stream, so
+// UseLazyReader panics if called on a nullable column.
+func (c *DateTime64) UseLazyReader(lazy *LazyReader) {
+	if c.nullable {
+		panic("chconn: UseLazyReader is not supported on a nullable column")
+	}
+	c.lazy = lazy
+}
+
+// readRow reads row i's raw ticks, from lazy when the column is in lazy
+// mode or from the buffered c.b otherwise.
+//
+// A read error from the lazy source (e.g. a truncated spill file) is fatal
+// to the block: the caller has no way to recover a partially decoded
+// result, so we panic rather than silently return a zero value.
+func (c *DateTime64) readRow(row int) time.Time {
+	if c.lazy != nil {
+		var buf [Uint64Size]byte
+		if err := c.lazy.ReadRow(buf[:], row); err != nil {
+			panic(fmt.Errorf("chconn: reading lazy row %d: %w", row, err))
+		}
+		return c.fromTicks(int64(binary.LittleEndian.Uint64(buf[:])))
+	}
+	return c.fromTicks(int64(binary.LittleEndian.Uint64(c.b[row*c.size : row*c.size+c.size])))
+}
+
+// Next forward pointer to the next value. Returns false if there are no more values.
+//
+// Use with Value() or ValueP()
+func (c *DateTime64) Next() bool {
+	if c.lazy != nil {
+		if c.i >= c.numRow {
+			return false
+		}
+		c.val = c.readRow(c.i)
+		c.i++
+		return true
+	}
+	if c.i >= c.totalByte {
+		return false
+	}
+	c.i += c.size
+	c.val = c.fromTicks(int64(binary.LittleEndian.Uint64(c.b[c.i-c.size : c.i])))
+	return true
+}
+
+// Value of current pointer
+//
+// Use with Next()
+func (c *DateTime64) Value() time.Time {
+	return c.val
+}
+
+// ReadAll read all value in this block and append to the input slice
+func (c *DateTime64) ReadAll(value *[]time.Time) {
+	if c.lazy != nil {
+		for i := 0; i < c.numRow; i++ {
+			*value = append(*value, c.readRow(i))
+		}
+		return
+	}
+	for i := 0; i < c.totalByte; i += c.size {
+		*value = append(*value,
+			c.fromTicks(int64(binary.LittleEndian.Uint64(c.b[i:i+c.size]))))
+	}
+}
+
+// Fill slice with value and forward the pointer by the length of the slice
+//
+// NOTE: A slice that is longer than the remaining data is not safe to pass.
+func (c *DateTime64) Fill(value []time.Time) {
+	if c.lazy != nil {
+		for i := range value {
+			value[i] = c.readRow(c.i)
+			c.i++
+		}
+		return
+	}
+	for i := range value {
+		value[i] = c.fromTicks(int64(binary.LittleEndian.Uint64(c.b[c.i : c.i+c.size])))
+		c.i += c.size
+	}
+}
+
+// ValueP Value of current pointer for nullable data
+//
+// As an alternative (for better performance), you can use `Value()` to get a value and `ValueIsNil()` to check if it is null.
+//
+// Use with Next()
+func (c *DateTime64) ValueP() *time.Time {
+	if c.colNullable.b[(c.i-c.size)/(c.size)] == 1 {
+		return nil
+	}
+	val := c.val
+	return &val
+}
+
+// ReadAllP read all value in this block and append to the input slice (for nullable data)
+func (c *DateTime64) ReadAllP(value *[]*time.Time) {
+	for i := 0; i < c.totalByte; i += c.size {
+		if c.colNullable.b[i/c.size] != 0 {
+			*value = append(*value, nil)
+			continue
+		}
+		val := c.fromTicks(int64(binary.LittleEndian.Uint64(c.b[i : i+c.size])))
+		*value = append(*value, &val)
+	}
+}
+
+// FillP slice with value and forward the pointer by the length of the slice (for nullable data)
+//
+// NOTE: A slice that is longer than the remaining data is not safe to pass.
+func (c *DateTime64) FillP(value []*time.Time) {
+	for i := range value {
+		if c.colNullable.b[c.i/c.size] == 1 {
+			value[i] = nil
+			c.i += c.size
+			continue
+		}
+		val := c.fromTicks(int64(binary.LittleEndian.Uint64(c.b[c.i : c.i+c.size])))
+		value[i] = &val
+		c.i += c.size
+	}
+}
+
+// Append value for insert
+//
+// Append returns an error if v's sub-second part isn't representable at the
+// column's precision (e.g. appending a nanosecond-precision time.Time into a
+// DateTime64(3) column).
+func (c *DateTime64) Append(v time.Time) error {
+	ticks, err := c.toTicks(v)
+	if err != nil {
+		return err
+	}
+	c.numRow++
+	c.writeTicks(ticks)
+	return nil
+}
+
+func (c *DateTime64) writeTicks(ticks int64) {
+	c.writerData = append(c.writerData,
+		byte(ticks),
+		byte(ticks>>8),
+		byte(ticks>>16),
+		byte(ticks>>24),
+		byte(ticks>>32),
+		byte(ticks>>40),
+		byte(ticks>>48),
+		byte(ticks>>56),
+	)
+}
+
+// AppendEmpty append empty value for insert
+func (c *DateTime64) AppendEmpty() {
+	c.numRow++
+	c.writerData = append(c.writerData, emptyByte[:c.size]...)
+}
+
+// AppendP value for insert (for nullable column)
+//
+// NOTE: for alternative mode. of your value is nil you still need to append default value. You can use `AppendEmpty()` for nil values
+func (c *DateTime64) AppendP(v *time.Time) error {
+	if v == nil {
+		c.AppendEmpty()
+		c.colNullable.Append(1)
+		return nil
+	}
+	c.colNullable.Append(0)
+	return c.Append(*v)
+}
+
+// AppendDict add value to the dictionary (if doesn't exist on dictionary) and append key of the dictionary to keys
+//
+// Only use for LowCardinality data type. The dictionary is keyed by the raw
+// tick value (not the decoded time.Time) so sub-second precision survives
+// the dictionary round-trip.
+func (c *DateTime64) AppendDict(v time.Time) error {
+	ticks, err := c.toTicks(v)
+	if err != nil {
+		return err
+	}
+	key, ok := c.dict[ticks]
+	if !ok {
+		key = len(c.dict)
+		c.dict[ticks] = key
+		c.numRow++
+		c.writeTicks(ticks)
+	}
+	if c.nullable {
+		c.keys = append(c.keys, key+1)
+	} else {
+		c.keys = append(c.keys, key)
+	}
+	return nil
+}
+
+// AppendDictNil add nil key for LowCardinality nullable data type
+func (c *DateTime64) AppendDictNil() {
+	c.keys = append(c.keys, 0)
+}
+
+// AppendDictP add value to the dictionary (if doesn't exist on dictionary)
+// and append key of the dictionary to keys (for nullable data type)
+//
+// As an alternative (for better performance), You can use `AppendDict()` and `AppendDictNil` instead of this function.
+//
+// For alternative way You shouldn't append empty value for nullable data
+func (c *DateTime64) AppendDictP(v *time.Time) error {
+	if v == nil {
+		c.keys = append(c.keys, 0)
+		return nil
+	}
+	ticks, err := c.toTicks(*v)
+	if err != nil {
+		return err
+	}
+	key, ok := c.dict[ticks]
+	if !ok {
+		key = len(c.dict)
+		c.dict[ticks] = key
+		c.numRow++
+		c.writeTicks(ticks)
+	}
+	c.keys = append(c.keys, key+1)
+	return nil
+}
+
+// Keys current keys for LowCardinality data type
+func (c *DateTime64) Keys() []int {
+	return c.keys
+}
+
+// Reset all status and buffer data
+//
+// Reading data does not require a reset after each read. The reset will be triggered automatically.
+//
+// However, writing data requires a reset after each write.
+func (c *DateTime64) Reset() {
+	c.column.Reset()
+	c.keys = c.keys[:0]
+	c.dict = make(map[int64]int)
+	if c.lazy != nil {
+		c.lazy.Close()
+		c.lazy = nil
+	}
+}
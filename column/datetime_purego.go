@@ -0,0 +1,43 @@
+//go:build purego || !(386 || amd64 || amd64p32 || arm || arm64 || mipsle || mips64le || mips64p32le || ppc64le || riscv || riscv64)
+// +build purego !386,!amd64,!amd64p32,!arm,!arm64,!mipsle,!mips64le,!mips64p32le,!ppc64le,!riscv,!riscv64
+
+package column
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// GetAllUnsafe get all the raw seconds in current block as a slice.
+//
+// On purego or big-endian builds this falls back to a decode loop so callers
+// can compile the API unconditionally.
+func (c *DateTime) GetAllUnsafe() []uint32 {
+	value := make([]uint32, 0, c.numRow)
+	c.ReadAllUnsafe(&value)
+	return value
+}
+
+// ReadAllUnsafe reads all the raw seconds in current block and append to value.
+//
+// On purego or big-endian builds this falls back to a decode loop so callers
+// can compile the API unconditionally.
+func (c *DateTime) ReadAllUnsafe(value *[]uint32) {
+	if c.lazy != nil {
+		panic("chconn: ReadAllUnsafe is not supported on a lazy column, use ReadAll instead")
+	}
+	for i := 0; i < c.totalByte; i += c.size {
+		*value = append(*value, binary.LittleEndian.Uint32(c.b[i:i+c.size]))
+	}
+}
+
+// GetAllUnsafeAsTime is like GetAllUnsafe, but converts every raw second to a
+// time.Time in loc.
+func (c *DateTime) GetAllUnsafeAsTime(loc *time.Location) []time.Time {
+	raw := c.GetAllUnsafe()
+	value := make([]time.Time, len(raw))
+	for i, v := range raw {
+		value[i] = time.Unix(int64(v), 0).In(loc)
+	}
+	return value
+}
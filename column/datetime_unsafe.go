@@ -0,0 +1,42 @@
+//go:build (386 || amd64 || amd64p32 || arm || arm64 || mipsle || mips64le || mips64p32le || ppc64le || riscv || riscv64) && !purego
+// +build 386 amd64 amd64p32 arm arm64 mipsle mips64le mips64p32le ppc64le riscv riscv64
+// +build !purego
+
+package column
+
+import "time"
+
+// GetAllUnsafe get all the raw seconds in current block as a slice, with no
+// per-row time.Unix allocation.
+//
+// Use GetAllUnsafeAsTime if you need []time.Time instead.
+//
+// NOTE: this function is unsafe and only can use in little-endian system cpu architecture.
+func (c *DateTime) GetAllUnsafe() []uint32 {
+	if c.lazy != nil {
+		panic("chconn: GetAllUnsafe is not supported on a lazy column, use ReadAll instead")
+	}
+	return getAllUnsafe[uint32](c.b, c.numRow)
+}
+
+// ReadAllUnsafe reads all the raw seconds in current block and append to value.
+//
+// NOTE: this function is unsafe and only can use in little-endian system  cpu architecture.
+func (c *DateTime) ReadAllUnsafe(value *[]uint32) {
+	if c.lazy != nil {
+		panic("chconn: ReadAllUnsafe is not supported on a lazy column, use ReadAll instead")
+	}
+	readAllUnsafe(value, c.b, c.numRow)
+}
+
+// GetAllUnsafeAsTime is like GetAllUnsafe, but converts every raw second to a
+// time.Time in loc. It lets callers opt out of the per-row time.Unix
+// allocation that otherwise dominates ReadAll for wide time-series blocks.
+func (c *DateTime) GetAllUnsafeAsTime(loc *time.Location) []time.Time {
+	raw := c.GetAllUnsafe()
+	value := make([]time.Time, len(raw))
+	for i, v := range raw {
+		value[i] = time.Unix(int64(v), 0).In(loc)
+	}
+	return value
+}
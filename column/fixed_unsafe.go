@@ -0,0 +1,31 @@
+//go:build (386 || amd64 || amd64p32 || arm || arm64 || mipsle || mips64le || mips64p32le || ppc64le || riscv || riscv64) && !purego
+// +build 386 amd64 amd64p32 arm arm64 mipsle mips64le mips64p32le ppc64le riscv riscv64
+// +build !purego
+
+package column
+
+import "unsafe"
+
+// Fixed is the set of Go types backing chconn's fixed-size columns: every
+// value whose wire representation is just its raw little-endian bytes.
+type Fixed interface {
+	~int8 | ~int16 | ~int32 | ~int64 |
+		~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// getAllUnsafe reinterprets a column's decoded buffer as a []T slice of
+// length numRow, with no copy.
+//
+// NOTE: this function is unsafe and only can use in little-endian system cpu architecture.
+func getAllUnsafe[T Fixed](b []byte, numRow int) []T {
+	value := *(*[]T)(unsafe.Pointer(&b))
+	return value[:numRow]
+}
+
+// readAllUnsafe reads a column's decoded buffer, reinterpreted as []T, and appends it to value.
+//
+// NOTE: this function is unsafe and only can use in little-endian system cpu architecture.
+func readAllUnsafe[T Fixed](value *[]T, b []byte, numRow int) {
+	*value = append(*value, getAllUnsafe[T](b, numRow)...)
+}
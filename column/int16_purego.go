@@ -0,0 +1,26 @@
+//go:build purego || !(386 || amd64 || amd64p32 || arm || arm64 || mipsle || mips64le || mips64p32le || ppc64le || riscv || riscv64)
+// +build purego !386,!amd64,!amd64p32,!arm,!arm64,!mipsle,!mips64le,!mips64p32le,!ppc64le,!riscv,!riscv64
+
+package column
+
+import "encoding/binary"
+
+// GetAllUnsafe get all the data in current block as a slice.
+//
+// On purego or big-endian builds this falls back to a decode loop so callers
+// can compile the API unconditionally.
+func (c *Int16) GetAllUnsafe() []int16 {
+	value := make([]int16, 0, c.numRow)
+	c.ReadAllUnsafe(&value)
+	return value
+}
+
+// ReadAllUnsafe reads all the data in current block and append to column.
+//
+// On purego or big-endian builds this falls back to a decode loop so callers
+// can compile the API unconditionally.
+func (c *Int16) ReadAllUnsafe(value *[]int16) {
+	for i := 0; i < c.totalByte; i += c.size {
+		*value = append(*value, int16(binary.LittleEndian.Uint16(c.b[i:i+c.size])))
+	}
+}
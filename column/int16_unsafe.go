@@ -4,22 +4,16 @@
 
 package column
 
-import (
-	"unsafe"
-)
-
 // GetAllUnsafe get all the data in current block as a slice.
 //
 // NOTE: this function is unsafe and only can use in little-endian system cpu architecture.
 func (c *Int16) GetAllUnsafe() []int16 {
-	value := *(*[]int16)(unsafe.Pointer(&c.b))
-	return value[:c.numRow]
+	return getAllUnsafe[int16](c.b, c.numRow)
 }
 
 // ReadAllUnsafe reads all the data in current block and append to column.
 //
 // NOTE: this function is unsafe and only can use in little-endian system  cpu architecture.
 func (c *Int16) ReadAllUnsafe(value *[]int16) {
-	v := *(*[]int16)(unsafe.Pointer(&c.b))
-	*value = append(*value, v[:c.numRow]...)
+	readAllUnsafe(value, c.b, c.numRow)
 }
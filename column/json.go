@@ -0,0 +1,521 @@
+package column
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/vahid-sohrabloo/chconn/v3/internal/readerwriter"
+)
+
+// JSON use for the native JSON / Object('json') ClickHouse DataType.
+//
+// ClickHouse sends Object('json') as a dynamic set of typed leaf columns,
+// one per dotted path (e.g. "user.id" -> Int64, "user.tags" -> Array(String)).
+// JSON materializes each leaf as a regular chconn column (String, Int64,
+// Float64, Array, nested JSON, ...) and re-assembles a json.RawMessage
+// document per row on demand, the same way the TiDB chunk implementation
+// stores a name/value pair per JSON field.
+type JSON struct {
+	column
+	val     json.RawMessage
+	leaves  map[string]ColumnBasic
+	paths   []string
+	cache   []json.RawMessage
+	fields  map[string][]any
+	flushed int
+}
+
+// NewJSON return new JSON for JSON / Object('json') ClickHouse DataType
+func NewJSON(nullable bool) *JSON {
+	return &JSON{
+		leaves: make(map[string]ColumnBasic),
+		fields: make(map[string][]any),
+		column: column{
+			nullable:    nullable,
+			colNullable: newNullable(),
+		},
+	}
+}
+
+// AddLeaf registers the chconn column backing path (e.g. "user.id") in
+// ClickHouse's dynamic Object('json') layout. ReadRaw calls this once per
+// leaf while decoding the block's subcolumn list, before any row is read.
+func (c *JSON) AddLeaf(path string, col ColumnBasic) {
+	if _, ok := c.leaves[path]; !ok {
+		c.paths = append(c.paths, path)
+	}
+	c.leaves[path] = col
+}
+
+// ReadRaw decodes ClickHouse's native wire layout for Object('json'): a
+// varint leaf count, followed by a (path, type) string pair per leaf, each
+// immediately followed by that leaf's own ReadRaw(num, r). Every leaf is
+// materialized via newLeafColumn and registered with AddLeaf so
+// RowPath/ToJSON/row never need to re-parse a type string per row.
+func (c *JSON) ReadRaw(num int, r *readerwriter.Reader) error {
+	numLeaves, err := r.Uvarint()
+	if err != nil {
+		return fmt.Errorf("chconn: reading JSON leaf count: %w", err)
+	}
+
+	c.leaves = make(map[string]ColumnBasic, numLeaves)
+	c.paths = c.paths[:0]
+
+	for i := uint64(0); i < numLeaves; i++ {
+		path, err := r.String()
+		if err != nil {
+			return fmt.Errorf("chconn: reading JSON leaf %d path: %w", i, err)
+		}
+		chType, err := r.String()
+		if err != nil {
+			return fmt.Errorf("chconn: reading JSON leaf %q type: %w", path, err)
+		}
+		leaf, err := newLeafColumn(chType)
+		if err != nil {
+			return fmt.Errorf("chconn: JSON leaf %q: %w", path, err)
+		}
+		rr, ok := leaf.(interface {
+			ReadRaw(num int, r *readerwriter.Reader) error
+		})
+		if !ok {
+			return fmt.Errorf("chconn: JSON leaf %q: %T does not support ReadRaw", path, leaf)
+		}
+		if err := rr.ReadRaw(num, r); err != nil {
+			return fmt.Errorf("chconn: reading JSON leaf %q: %w", path, err)
+		}
+		c.AddLeaf(path, leaf)
+	}
+
+	c.numRow = num
+	return nil
+}
+
+// newLeafColumn materializes the chconn column backing a JSON leaf's
+// ClickHouse type string, e.g. "String", "Int64", "Nullable(Float64)", or a
+// nested "JSON"/"Object('json')" document.
+func newLeafColumn(chType string) (ColumnBasic, error) {
+	nullable := strings.HasPrefix(chType, "Nullable(")
+	base := chType
+	if nullable {
+		base = strings.TrimSuffix(strings.TrimPrefix(chType, "Nullable("), ")")
+	}
+	switch {
+	case base == "String":
+		return NewString(nullable), nil
+	case base == "Int64":
+		return NewInt64(nullable), nil
+	case base == "Float64":
+		return NewFloat64(nullable), nil
+	case base == "JSON" || strings.HasPrefix(base, "Object("):
+		return NewJSON(nullable), nil
+	case strings.HasPrefix(base, "Array(") && strings.HasSuffix(base, ")"):
+		elemType := base[len("Array(") : len(base)-1]
+		return newJSONArrayLeaf(elemType)
+	default:
+		return nil, fmt.Errorf("unsupported JSON leaf type %q", chType)
+	}
+}
+
+// jsonArrayLeaf decodes a JSON leaf reported as Array(T): ClickHouse's
+// native Array(T) wire layout is a UInt64 cumulative-offset per row
+// (offsets[i] is the end, exclusive, of row i's elements in the nested
+// column) followed by the nested column's own ReadRaw for the total
+// element count. It's implemented directly here, rather than through the
+// generic Array[T], so a JSON array leaf's element type (itself resolved
+// via newLeafColumn) can be picked at decode time from the wire's type
+// string instead of a compile-time type parameter.
+type jsonArrayLeaf struct {
+	elem    ColumnBasic
+	offsets []uint64
+}
+
+func newJSONArrayLeaf(elemType string) (*jsonArrayLeaf, error) {
+	elem, err := newLeafColumn(elemType)
+	if err != nil {
+		return nil, fmt.Errorf("JSON array element: %w", err)
+	}
+	return &jsonArrayLeaf{elem: elem}, nil
+}
+
+// ReadRaw decodes this leaf's offsets, then the nested element column.
+func (a *jsonArrayLeaf) ReadRaw(num int, r *readerwriter.Reader) error {
+	a.offsets = make([]uint64, num)
+	for i := 0; i < num; i++ {
+		off, err := r.Uint64()
+		if err != nil {
+			return fmt.Errorf("chconn: reading JSON array offset %d: %w", i, err)
+		}
+		a.offsets[i] = off
+	}
+	numElems := 0
+	if num > 0 {
+		numElems = int(a.offsets[num-1])
+	}
+	rr, ok := a.elem.(interface {
+		ReadRaw(num int, r *readerwriter.Reader) error
+	})
+	if !ok {
+		return fmt.Errorf("chconn: JSON array element %T does not support ReadRaw", a.elem)
+	}
+	return rr.ReadRaw(numElems, r)
+}
+
+func (a *jsonArrayLeaf) bounds(row int) (uint64, uint64) {
+	var start uint64
+	if row != 0 {
+		start = a.offsets[row-1]
+	}
+	return start, a.offsets[row]
+}
+
+// RowAny returns row's elements as a []any, so an Array(T) leaf reassembles
+// into the same shape encoding/json would have produced from the original document.
+func (a *jsonArrayLeaf) RowAny(row int) any {
+	start, end := a.bounds(row)
+	out := make([]any, 0, end-start)
+	for i := start; i < end; i++ {
+		out = append(out, a.elem.RowAny(int(i)))
+	}
+	return out
+}
+
+// ToJSON writes row's elements as a JSON array.
+func (a *jsonArrayLeaf) ToJSON(row int, ignoreDoubleQuotes bool, b []byte) []byte {
+	start, end := a.bounds(row)
+	b = append(b, '[')
+	for i := start; i < end; i++ {
+		if i != start {
+			b = append(b, ',')
+		}
+		b = a.elem.ToJSON(int(i), ignoreDoubleQuotes, b)
+	}
+	return append(b, ']')
+}
+
+// Next forward pointer to the next value. Returns false if there are no more values.
+//
+// Use with Value()
+func (c *JSON) Next() bool {
+	if c.i >= c.numRow {
+		return false
+	}
+	c.val = c.row(c.i)
+	c.i++
+	return true
+}
+
+// Value of current pointer
+//
+// Use with Next()
+func (c *JSON) Value() json.RawMessage {
+	return c.val
+}
+
+// ReadAll read all value in this block and append to the input slice
+func (c *JSON) ReadAll(value *[]json.RawMessage) {
+	for i := 0; i < c.numRow; i++ {
+		*value = append(*value, c.row(i))
+	}
+}
+
+// Fill slice with value and forward the pointer by the length of the slice
+//
+// NOTE: A slice that is longer than the remaining data is not safe to pass.
+func (c *JSON) Fill(value []json.RawMessage) {
+	for i := range value {
+		value[i] = c.row(c.i)
+		c.i++
+	}
+}
+
+// RowPath returns a single field of row, addressed by its dotted path,
+// without paying the cost of re-serializing the whole document: it reads
+// straight from the leaf column backing path.
+func (c *JSON) RowPath(row int, path string) any {
+	leaf, ok := c.leaves[path]
+	if !ok {
+		return nil
+	}
+	return leaf.RowAny(row)
+}
+
+// RowAny returns the value of given row, so a JSON column can itself be used
+// as a leaf of an outer JSON column (nested Object('json') documents).
+//
+// NOTE: Row number start from zero
+func (c *JSON) RowAny(row int) any {
+	return c.row(row)
+}
+
+// row re-assembles row's full JSON document from its leaf columns.
+func (c *JSON) row(row int) json.RawMessage {
+	b := make([]byte, 0, 64)
+	b = append(b, '{')
+	for i, path := range c.paths {
+		if i != 0 {
+			b = append(b, ',')
+		}
+		b = append(b, '"')
+		b = append(b, strings.ReplaceAll(path, `"`, `\"`)...)
+		b = append(b, `":`...)
+		b = c.leaves[path].ToJSON(row, false, b)
+	}
+	b = append(b, '}')
+	return json.RawMessage(b)
+}
+
+// ToJSON writes row's reconstructed document directly to b instead of
+// double-encoding an already-built json.RawMessage.
+func (c *JSON) ToJSON(row int, ignoreDoubleQuotes bool, b []byte) []byte {
+	return append(b, c.row(row)...)
+}
+
+// Append value for insert. v may be a json.RawMessage, a []byte holding
+// already-encoded JSON, or anything implementing json.Marshaler.
+func (c *JSON) Append(v any) error {
+	raw, err := marshalJSON(v)
+	if err != nil {
+		return err
+	}
+	c.numRow++
+	c.cache = append(c.cache, raw)
+	return nil
+}
+
+// AppendEmpty append empty value for insert
+func (c *JSON) AppendEmpty() {
+	c.numRow++
+	c.cache = append(c.cache, json.RawMessage("{}"))
+}
+
+// AppendP value for insert (for nullable column)
+//
+// NOTE: for alternative mode. of your value is nil you still need to append default value. You can use `AppendEmpty()` for nil values
+func (c *JSON) AppendP(v any) error {
+	if v == nil {
+		c.AppendEmpty()
+		c.colNullable.Append(1)
+		return nil
+	}
+	c.colNullable.Append(0)
+	return c.Append(v)
+}
+
+func marshalJSON(v any) (json.RawMessage, error) {
+	switch t := v.(type) {
+	case json.RawMessage:
+		return t, nil
+	case []byte:
+		return json.RawMessage(t), nil
+	case json.Marshaler:
+		return t.MarshalJSON()
+	default:
+		return json.Marshal(v)
+	}
+}
+
+// Flush materializes every row staged by Append/AppendP since the last call
+// into writerData, so inserted JSON values actually reach the wire instead
+// of sitting untouched in cache. It is idempotent: the insert path can call
+// it once per flush of a larger batch.
+//
+// Flush flattens each cached document into dotted-path/value pairs (nested
+// objects become "parent.child" paths, same as the read side's leaves) and
+// writes them in the exact shape ReadRaw decodes: a varint leaf count, then
+// per leaf a (path, type) string pair — "Float64", "String", or
+// "Nullable(...)" of either if any row's value is absent — followed by that
+// leaf's native row bytes: a ClickHouse-style leading null bitmap (one byte
+// per row) when nullable, then the plain values (8 little-endian bytes per
+// Float64, a varint length prefix plus raw bytes per String). A path
+// introduced partway through the batch is backfilled as absent for every
+// earlier row.
+//
+// Array and boolean values, and a document that is not a JSON object (or
+// null), aren't supported by the insert path and make Flush return an
+// error rather than silently drop data.
+func (c *JSON) Flush() error {
+	for row := c.flushed; row < len(c.cache); row++ {
+		flat := make(map[string]any)
+		if c.cache[row] != nil {
+			var decoded any
+			if err := json.Unmarshal(c.cache[row], &decoded); err != nil {
+				return fmt.Errorf("chconn: flushing JSON row %d: %w", row, err)
+			}
+			if m, ok := decoded.(map[string]any); ok {
+				if err := flattenJSON("", m, flat); err != nil {
+					return fmt.Errorf("chconn: flushing JSON row %d: %w", row, err)
+				}
+			} else if decoded != nil {
+				return fmt.Errorf("chconn: flushing JSON row %d: document is %T, not an object", row, decoded)
+			}
+		}
+
+		for path, v := range flat {
+			if _, ok := c.fields[path]; !ok {
+				c.fields[path] = make([]any, row)
+				c.paths = append(c.paths, path)
+			}
+			c.fields[path] = append(c.fields[path], v)
+		}
+		for path, values := range c.fields {
+			if len(values) == row {
+				c.fields[path] = append(values, nil)
+			}
+		}
+	}
+	c.flushed = len(c.cache)
+
+	c.writerData = c.writerData[:0]
+	c.writerData = binary.AppendUvarint(c.writerData, uint64(len(c.paths)))
+	for _, path := range c.paths {
+		values := c.fields[path]
+		chType, err := jsonLeafType(values)
+		if err != nil {
+			return fmt.Errorf("chconn: flushing JSON path %q: %w", path, err)
+		}
+
+		c.writerData = binary.AppendUvarint(c.writerData, uint64(len(path)))
+		c.writerData = append(c.writerData, path...)
+		c.writerData = binary.AppendUvarint(c.writerData, uint64(len(chType)))
+		c.writerData = append(c.writerData, chType...)
+
+		nullable := strings.HasPrefix(chType, "Nullable(")
+		base := chType
+		if nullable {
+			base = strings.TrimSuffix(strings.TrimPrefix(chType, "Nullable("), ")")
+			for _, v := range values {
+				if v == nil {
+					c.writerData = append(c.writerData, 1)
+				} else {
+					c.writerData = append(c.writerData, 0)
+				}
+			}
+		}
+
+		for _, v := range values {
+			c.writerData, err = appendJSONLeafValue(c.writerData, base, v)
+			if err != nil {
+				return fmt.Errorf("chconn: flushing JSON path %q: %w", path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// flattenJSON walks v (the result of json.Unmarshal into `any`), writing a
+// prefix.child-style dotted path entry to out for every scalar leaf.
+func flattenJSON(prefix string, v any, out map[string]any) error {
+	m, ok := v.(map[string]any)
+	if !ok {
+		out[prefix] = v
+		return nil
+	}
+	for k, child := range m {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+		if nested, ok := child.(map[string]any); ok {
+			if err := flattenJSON(path, nested, out); err != nil {
+				return err
+			}
+			continue
+		}
+		switch child.(type) {
+		case string, float64, nil:
+			out[path] = child
+		default:
+			return fmt.Errorf("path %q: unsupported JSON leaf value %T", path, child)
+		}
+	}
+	return nil
+}
+
+// jsonLeafType picks a leaf's ClickHouse type string from the Go types of
+// its non-nil values, wrapped in Nullable(...) if any row's value is
+// absent — the same (path, type) shape ReadRaw parses off the wire. An
+// all-null column defaults to Nullable(String).
+func jsonLeafType(values []any) (string, error) {
+	nullable := false
+	base := ""
+	for _, v := range values {
+		if v == nil {
+			nullable = true
+			continue
+		}
+		var t string
+		switch v.(type) {
+		case float64:
+			t = "Float64"
+		case string:
+			t = "String"
+		default:
+			return "", fmt.Errorf("unsupported JSON leaf value %T", v)
+		}
+		if base == "" {
+			base = t
+		} else if base != t {
+			return "", fmt.Errorf("mixed JSON leaf types %s and %s", base, t)
+		}
+	}
+	if base == "" {
+		base = "String"
+	}
+	if nullable {
+		return "Nullable(" + base + ")", nil
+	}
+	return base, nil
+}
+
+// appendJSONLeafValue appends v's native wire bytes for base ("Float64" or
+// "String") to buf: 8 little-endian bytes for Float64, a varint length
+// prefix plus raw bytes for String. v is nil for an absent row, whose null
+// bit was already written by Flush; the default zero value still has to be
+// written here since ClickHouse's Nullable wire format pairs a null bitmap
+// with a same-length underlying column.
+func appendJSONLeafValue(buf []byte, base string, v any) ([]byte, error) {
+	switch base {
+	case "Float64":
+		var f float64
+		if v != nil {
+			var ok bool
+			f, ok = v.(float64)
+			if !ok {
+				return nil, fmt.Errorf("expected float64, got %T", v)
+			}
+		}
+		var tmp [8]byte
+		binary.LittleEndian.PutUint64(tmp[:], math.Float64bits(f))
+		return append(buf, tmp[:]...), nil
+	case "String":
+		var s string
+		if v != nil {
+			var ok bool
+			s, ok = v.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected string, got %T", v)
+			}
+		}
+		buf = binary.AppendUvarint(buf, uint64(len(s)))
+		return append(buf, s...), nil
+	default:
+		return nil, fmt.Errorf("unknown JSON leaf type %q", base)
+	}
+}
+
+// Reset all status and buffer data
+//
+// Reading data does not require a reset after each read. The reset will be triggered automatically.
+//
+// However, writing data requires a reset after each write.
+func (c *JSON) Reset() {
+	c.column.Reset()
+	c.cache = c.cache[:0]
+	c.leaves = make(map[string]ColumnBasic)
+	c.paths = c.paths[:0]
+	c.fields = make(map[string][]any)
+	c.flushed = 0
+}
@@ -0,0 +1,148 @@
+package column
+
+import (
+	"io"
+	"os"
+)
+
+// DefaultMmapThreshold is the spilled-size cutoff SpillToFile uses when the
+// caller doesn't pick one explicitly: data at or above this many bytes is
+// mmap'd instead of left behind a plain *os.File, so a windowed ReadRow over
+// an oversized block doesn't pay a read syscall per row once the file is
+// paged in. Below it, the temp file is used as-is; mmap's per-call overhead
+// isn't worth it for small spills.
+const DefaultMmapThreshold = 64 << 20 // 64 MiB
+
+// LazyReader backs a column's decoded block with an io.ReaderAt instead of
+// buffering the whole thing in c.b, so ReadAll/Fill over a huge block (e.g.
+// a million-row Uint64 block, which otherwise pins 8 MiB even when the
+// caller only needs a windowed scan) reads exactly c.size bytes per row on
+// demand, the way SeaweedFS's columnar ColumnUint16.Read(buf, readerAt,
+// offset, i) does.
+//
+// Only a column's plain value stream is read through a LazyReader (see
+// UseLazyReader); LowCardinality keys and dictionaries are not read lazily.
+type LazyReader struct {
+	r      io.ReaderAt
+	offset int64
+	file   *os.File
+	mapped []byte
+}
+
+// NewLazyReader wraps r, an io.ReaderAt already positioned so that row 0
+// starts at offset, as a lazy backing source for a column.
+func NewLazyReader(r io.ReaderAt, offset int64) *LazyReader {
+	return &LazyReader{r: r, offset: offset}
+}
+
+// NewLazyReaderIfOversized returns a LazyReader for r, or nil if blockSize
+// is below threshold or nullable is true, so a block decoder can apply a
+// lazy-column size threshold, which it passes in, as:
+//
+//	lazy, err := column.NewLazyReaderIfOversized(r, blockSize, threshold, col.Nullable())
+//	if err != nil {
+//	    return err
+//	}
+//	if lazy != nil {
+//	    col.UseLazyReader(lazy)
+//	} else {
+//	    // decode the block into col.b as usual
+//	}
+//
+// Every lazy-capable column's UseLazyReader panics on a nullable column
+// (lazy mode only covers the plain value stream, not colNullable's
+// byte-offset indexing), so nullable is checked here rather than left for
+// UseLazyReader to reject: under the call sequence above, a nullable column
+// at or above threshold degrades to ordinary buffered decoding instead of
+// panicking.
+//
+// A threshold <= 0 disables lazy mode entirely.
+func NewLazyReaderIfOversized(r io.Reader, blockSize, threshold int, nullable bool) (*LazyReader, error) {
+	if threshold <= 0 || blockSize < threshold || nullable {
+		return nil, nil
+	}
+	return SpillToFile(r)
+}
+
+// SpillToFile copies r's bytes to a temp file and returns a LazyReader
+// backed by it, for sources (such as a decompressed network stream) that
+// aren't already an io.ReaderAt. Once the spilled data reaches
+// DefaultMmapThreshold, the file is mmap'd instead of read through the
+// normal buffered file path. The caller owns the result and must Close it
+// once the column is done with it.
+func SpillToFile(r io.Reader) (*LazyReader, error) {
+	return SpillToFileWithMmapThreshold(r, DefaultMmapThreshold)
+}
+
+// SpillToFileWithMmapThreshold is SpillToFile with an explicit mmap cutoff,
+// for callers that already know the source is large (or small) enough that
+// DefaultMmapThreshold is the wrong choice.
+func SpillToFileWithMmapThreshold(r io.Reader, mmapThreshold int64) (*LazyReader, error) {
+	f, err := os.CreateTemp("", "chconn-lazy-*")
+	if err != nil {
+		return nil, err
+	}
+	size, err := io.Copy(f, r)
+	if err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, err
+	}
+
+	if size < mmapThreshold {
+		return &LazyReader{r: f, file: f}, nil
+	}
+
+	data, err := mmapFile(f, size)
+	if err != nil {
+		// mmap isn't available (e.g. unsupported platform): fall back to
+		// the plain file path rather than failing the whole spill.
+		return &LazyReader{r: f, file: f}, nil
+	}
+	return &LazyReader{r: bytesReaderAt(data), file: f, mapped: data}, nil
+}
+
+// bytesReaderAt is an io.ReaderAt view over an mmap'd []byte.
+type bytesReaderAt []byte
+
+func (b bytesReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off >= int64(len(b)) {
+		return 0, io.EOF
+	}
+	n := copy(p, b[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// ReadRow reads exactly len(buf) bytes for the given zero-based row.
+func (l *LazyReader) ReadRow(buf []byte, row int) error {
+	_, err := l.r.ReadAt(buf, l.offset+int64(row)*int64(len(buf)))
+	return err
+}
+
+// Close releases the backing spill file and, if the spill was mmap'd, the
+// mapping itself, so long-lived pooled connections don't leak temp files or
+// mapped memory. It is a no-op when the LazyReader wraps a caller-owned
+// io.ReaderAt rather than a spilled file.
+func (l *LazyReader) Close() error {
+	if l.file == nil {
+		return nil
+	}
+	if l.mapped != nil {
+		if err := munmapFile(l.mapped); err != nil {
+			l.file.Close()
+			os.Remove(l.file.Name())
+			l.file = nil
+			l.mapped = nil
+			return err
+		}
+		l.mapped = nil
+	}
+	name := l.file.Name()
+	err := l.file.Close()
+	os.Remove(name)
+	l.file = nil
+	return err
+}
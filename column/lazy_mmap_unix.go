@@ -0,0 +1,20 @@
+//go:build !windows
+// +build !windows
+
+package column
+
+import (
+	"os"
+	"syscall"
+)
+
+// mmapFile maps f's first size bytes read-only into the process's address
+// space.
+func mmapFile(f *os.File, size int64) ([]byte, error) {
+	return syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+}
+
+// munmapFile releases a mapping returned by mmapFile.
+func munmapFile(data []byte) error {
+	return syscall.Munmap(data)
+}
@@ -0,0 +1,20 @@
+//go:build windows
+// +build windows
+
+package column
+
+import (
+	"fmt"
+	"os"
+)
+
+// mmapFile is unsupported on windows; SpillToFileWithMmapThreshold falls
+// back to the plain *os.File path when this returns an error.
+func mmapFile(f *os.File, size int64) ([]byte, error) {
+	return nil, fmt.Errorf("chconn: mmap is not supported on windows")
+}
+
+// munmapFile is never called on windows since mmapFile always errors.
+func munmapFile(data []byte) error {
+	return nil
+}
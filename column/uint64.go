@@ -2,14 +2,17 @@ package column
 
 import (
 	"encoding/binary"
+	"fmt"
 )
 
 // Uint64 use for UInt64 ClickHouse DataType
 type Uint64 struct {
 	column
-	val  uint64
-	dict map[uint64]int
-	keys []int
+	val   uint64
+	dict  map[uint64]int
+	keys  []int
+	lazy  *LazyReader
+	craft *CraftColumn
 }
 
 // NewUint64 return new Uint64 for UInt64 ClickHouse DataType
@@ -24,10 +27,63 @@ func NewUint64(nullable bool) *Uint64 {
 	}
 }
 
+// UseLazyReader switches the column into lazy mode: instead of indexing
+// into a fully buffered c.b, Next/ReadAll/Fill read c.size bytes per row
+// from lazy on demand. Call it once per block, before Next/ReadAll/Fill,
+// when the block's size is at or above the caller's lazy-column threshold —
+// see NewLazyReaderIfOversized, which a block decoder calls to turn that
+// threshold into a lazy column or nil.
+//
+// Lazy mode only covers the plain (non-nullable) value stream, so
+// UseLazyReader panics if called on a nullable column. GetAllUnsafe and
+// ReadAllUnsafe can't take a zero-copy view over a lazy source, so they
+// panic on a column in lazy mode.
+func (c *Uint64) UseLazyReader(lazy *LazyReader) {
+	if c.nullable {
+		panic("chconn: UseLazyReader is not supported on a nullable column")
+	}
+	c.lazy = lazy
+}
+
+// UseCraftBuffer switches Append/AppendEmpty/AppendP into staging through
+// craft instead of growing the column's own writerData slab, so the column
+// stages group-varint packed values in its CraftBuffer region instead of
+// 8 bytes per row. Call it once per insert, before any Append, with a
+// CraftColumn obtained from CraftBuffer.Column using CraftUint64; a
+// narrower kind truncates every value to its own width at WriteTo.
+func (c *Uint64) UseCraftBuffer(craft *CraftColumn) {
+	c.craft = craft
+}
+
+// readRow reads row i's bytes, from lazy when the column is in lazy mode or
+// from the buffered c.b otherwise.
+//
+// A read error from the lazy source (e.g. a truncated spill file) is fatal
+// to the block: the caller has no way to recover a partially decoded
+// result, so we panic rather than silently return a zero value.
+func (c *Uint64) readRow(row int) uint64 {
+	if c.lazy != nil {
+		var buf [Uint64Size]byte
+		if err := c.lazy.ReadRow(buf[:], row); err != nil {
+			panic(fmt.Errorf("chconn: reading lazy row %d: %w", row, err))
+		}
+		return binary.LittleEndian.Uint64(buf[:])
+	}
+	return binary.LittleEndian.Uint64(c.b[row*c.size : row*c.size+c.size])
+}
+
 // Next forward pointer to the next value. Returns false if there are no more values.
 //
 // Use with Value() or ValueP()
 func (c *Uint64) Next() bool {
+	if c.lazy != nil {
+		if c.i >= c.numRow {
+			return false
+		}
+		c.val = c.readRow(c.i)
+		c.i++
+		return true
+	}
 	if c.i >= c.totalByte {
 		return false
 	}
@@ -45,6 +101,12 @@ func (c *Uint64) Value() uint64 {
 
 // ReadAll read all value in this block and append to the input slice
 func (c *Uint64) ReadAll(value *[]uint64) {
+	if c.lazy != nil {
+		for i := 0; i < c.numRow; i++ {
+			*value = append(*value, c.readRow(i))
+		}
+		return
+	}
 	for i := 0; i < c.totalByte; i += c.size {
 		*value = append(*value,
 			binary.LittleEndian.Uint64(c.b[i:i+c.size]))
@@ -55,6 +117,13 @@ func (c *Uint64) ReadAll(value *[]uint64) {
 //
 // NOTE: A slice that is longer than the remaining data is not safe to pass.
 func (c *Uint64) Fill(value []uint64) {
+	if c.lazy != nil {
+		for i := range value {
+			value[i] = c.readRow(c.i)
+			c.i++
+		}
+		return
+	}
 	for i := range value {
 		value[i] = binary.LittleEndian.Uint64(c.b[c.i : c.i+c.size])
 		c.i += c.size
@@ -109,6 +178,10 @@ func (c *Uint64) FillP(value []*uint64) {
 // Append value for insert
 func (c *Uint64) Append(v uint64) {
 	c.numRow++
+	if c.craft != nil {
+		c.craft.AppendUint(v)
+		return
+	}
 	c.writerData = append(c.writerData,
 		byte(v),
 		byte(v>>8),
@@ -124,6 +197,10 @@ func (c *Uint64) Append(v uint64) {
 // AppendEmpty append empty value for insert
 func (c *Uint64) AppendEmpty() {
 	c.numRow++
+	if c.craft != nil {
+		c.craft.AppendUint(0)
+		return
+	}
 	c.writerData = append(c.writerData, emptyByte[:c.size]...)
 }
 
@@ -135,10 +212,18 @@ func (c *Uint64) AppendEmpty() {
 func (c *Uint64) AppendP(v *uint64) {
 	if v == nil {
 		c.AppendEmpty()
-		c.colNullable.Append(1)
+		if c.craft != nil {
+			c.craft.AppendNullBit(true)
+		} else {
+			c.colNullable.Append(1)
+		}
 		return
 	}
-	c.colNullable.Append(0)
+	if c.craft != nil {
+		c.craft.AppendNullBit(false)
+	} else {
+		c.colNullable.Append(0)
+	}
 	c.Append(*v)
 }
 
@@ -189,6 +274,38 @@ func (c *Uint64) Keys() []int {
 	return c.keys
 }
 
+// DictValues returns the dictionary values for LowCardinality data type, ordered by their key.
+//
+// Only use for LowCardinality data type
+func (c *Uint64) DictValues() []uint64 {
+	values := make([]uint64, len(c.dict))
+	for v, k := range c.dict {
+		values[k] = v
+	}
+	return values
+}
+
+// DictValuesP returns the dictionary values for LowCardinality data type, indexed
+// the same way as Keys(): if the column is nullable, index 0 is the null
+// sentinel (represented as a nil entry) and dictionary values start at index 1.
+//
+// Only use for LowCardinality data type
+func (c *Uint64) DictValuesP() []*uint64 {
+	values := c.DictValues()
+	if !c.nullable {
+		out := make([]*uint64, len(values))
+		for i := range values {
+			out[i] = &values[i]
+		}
+		return out
+	}
+	out := make([]*uint64, len(values)+1)
+	for i := range values {
+		out[i+1] = &values[i]
+	}
+	return out
+}
+
 // Reset all status and buffer data
 //
 // Reading data does not require a reset after each read. The reset will be triggered automatically.
@@ -198,4 +315,8 @@ func (c *Uint64) Reset() {
 	c.column.Reset()
 	c.keys = c.keys[:0]
 	c.dict = make(map[uint64]int)
+	if c.lazy != nil {
+		c.lazy.Close()
+		c.lazy = nil
+	}
 }
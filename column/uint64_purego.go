@@ -0,0 +1,38 @@
+//go:build purego || !(386 || amd64 || amd64p32 || arm || arm64 || mipsle || mips64le || mips64p32le || ppc64le || riscv || riscv64)
+// +build purego !386,!amd64,!amd64p32,!arm,!arm64,!mipsle,!mips64le,!mips64p32le,!ppc64le,!riscv,!riscv64
+
+package column
+
+import "encoding/binary"
+
+// GetAllUnsafe get all the data in current block as a slice.
+//
+// On purego or big-endian builds this falls back to a decode loop so callers
+// can compile the API unconditionally.
+//
+// GetAllUnsafe panics on a column in lazy mode (see UseLazyReader); use
+// ReadAll instead.
+func (c *Uint64) GetAllUnsafe() []uint64 {
+	if c.lazy != nil {
+		panic("chconn: GetAllUnsafe is not supported on a lazy column, use ReadAll instead")
+	}
+	value := make([]uint64, 0, c.numRow)
+	c.ReadAllUnsafe(&value)
+	return value
+}
+
+// ReadAllUnsafe reads all the data in current block and append to column.
+//
+// On purego or big-endian builds this falls back to a decode loop so callers
+// can compile the API unconditionally.
+//
+// ReadAllUnsafe panics on a column in lazy mode (see UseLazyReader); use
+// ReadAll instead.
+func (c *Uint64) ReadAllUnsafe(value *[]uint64) {
+	if c.lazy != nil {
+		panic("chconn: ReadAllUnsafe is not supported on a lazy column, use ReadAll instead")
+	}
+	for i := 0; i < c.totalByte; i += c.size {
+		*value = append(*value, binary.LittleEndian.Uint64(c.b[i:i+c.size]))
+	}
+}
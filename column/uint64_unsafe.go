@@ -0,0 +1,31 @@
+//go:build (386 || amd64 || amd64p32 || arm || arm64 || mipsle || mips64le || mips64p32le || ppc64le || riscv || riscv64) && !purego
+// +build 386 amd64 amd64p32 arm arm64 mipsle mips64le mips64p32le ppc64le riscv riscv64
+// +build !purego
+
+package column
+
+// GetAllUnsafe get all the data in current block as a slice.
+//
+// NOTE: this function is unsafe and only can use in little-endian system cpu architecture.
+//
+// GetAllUnsafe panics on a column in lazy mode (see UseLazyReader): there is
+// no buffered c.b to take a zero-copy view over, so use ReadAll instead.
+func (c *Uint64) GetAllUnsafe() []uint64 {
+	if c.lazy != nil {
+		panic("chconn: GetAllUnsafe is not supported on a lazy column, use ReadAll instead")
+	}
+	return getAllUnsafe[uint64](c.b, c.numRow)
+}
+
+// ReadAllUnsafe reads all the data in current block and append to column.
+//
+// NOTE: this function is unsafe and only can use in little-endian system  cpu architecture.
+//
+// ReadAllUnsafe panics on a column in lazy mode (see UseLazyReader); use
+// ReadAll instead.
+func (c *Uint64) ReadAllUnsafe(value *[]uint64) {
+	if c.lazy != nil {
+		panic("chconn: ReadAllUnsafe is not supported on a lazy column, use ReadAll instead")
+	}
+	readAllUnsafe(value, c.b, c.numRow)
+}